@@ -1,3 +1,5 @@
 module github.com/karimagnusson/go-picker
 
-go 1.23
\ No newline at end of file
+go 1.23
+
+require gopkg.in/yaml.v3 v3.0.1
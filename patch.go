@@ -0,0 +1,220 @@
+package picker
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var errPathNotFound = errors.New("path not found")
+
+// ApplyPatch applies an RFC 6902 JSON Patch document. The operations are
+// applied to a copy of the data, so either all of them succeed or the data
+// is left unchanged.
+func (p *Picker) ApplyPatch(patchJson string) error {
+	var ops []interface{}
+	err := decodeJson(strings.NewReader(patchJson), &ops)
+	if err != nil {
+		return err
+	}
+	var doc interface{} = deepCopy(p.data)
+	for i, rawOp := range ops {
+		doc, err = applyPatchOp(doc, rawOp)
+		if err != nil {
+			opName := ""
+			if op, ok := rawOp.(map[string]interface{}); ok {
+				opName, _ = op["op"].(string)
+			}
+			return fmt.Errorf("patch operation %d (%s): %w", i, opName, err)
+		}
+	}
+	data, ok := doc.(map[string]interface{})
+	if !ok {
+		return errors.New("patch result is not an object")
+	}
+	if p.data == nil {
+		p.data = map[string]interface{}{}
+	}
+	clear(p.data)
+	for key, value := range data {
+		p.data[key] = value
+	}
+	return nil
+}
+
+func applyPatchOp(doc interface{}, rawOp interface{}) (interface{}, error) {
+	op, ok := rawOp.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("operation is not an object")
+	}
+	path, ok := op["path"].(string)
+	if !ok {
+		return nil, errors.New("missing path")
+	}
+	tokens, ok := parsePointer(path)
+	if !ok {
+		return nil, errors.New("invalid path " + path)
+	}
+	value, hasValue := op["value"]
+	name, _ := op["op"].(string)
+
+	switch name {
+	case "add", "replace", "test":
+		if !hasValue {
+			return nil, errors.New("missing value")
+		}
+	case "move", "copy":
+		from, ok := op["from"].(string)
+		if !ok {
+			return nil, errors.New("missing from")
+		}
+		fromTokens, ok := parsePointer(from)
+		if !ok {
+			return nil, errors.New("invalid from " + from)
+		}
+		value, ok = resolvePointer(doc, fromTokens)
+		if !ok {
+			return nil, errors.New("from " + from + " not found")
+		}
+		if name == "copy" {
+			return pointerAdd(doc, tokens, deepCopy(value))
+		}
+		if path == from {
+			return doc, nil
+		}
+		if strings.HasPrefix(path, from+"/") {
+			return nil, errors.New("cannot move " + from + " into itself")
+		}
+		doc, err := pointerRemove(doc, fromTokens)
+		if err != nil {
+			return nil, err
+		}
+		return pointerAdd(doc, tokens, value)
+	}
+
+	switch name {
+	case "add":
+		return pointerAdd(doc, tokens, value)
+	case "remove":
+		return pointerRemove(doc, tokens)
+	case "replace":
+		return pointerReplace(doc, tokens, value)
+	case "test":
+		current, ok := resolvePointer(doc, tokens)
+		if !ok {
+			return nil, errPathNotFound
+		}
+		if !equalValues(current, value) {
+			return nil, errors.New("test failed at " + path)
+		}
+		return doc, nil
+	}
+	return nil, errors.New("unknown operation")
+}
+
+func pointerAdd(doc interface{}, tokens []string, value interface{}) (interface{}, error) {
+	if len(tokens) == 0 {
+		return value, nil
+	}
+	return pointerEdit(doc, tokens, func(parent interface{}, token string) (interface{}, error) {
+		switch v := parent.(type) {
+		case map[string]interface{}:
+			v[token] = value
+			return v, nil
+		case []interface{}:
+			index := len(v)
+			if token != "-" {
+				var ok bool
+				index, ok = pointerIndex(token, len(v)+1)
+				if !ok {
+					return nil, errors.New("index " + token + " out of range")
+				}
+			}
+			v = append(v, nil)
+			copy(v[index+1:], v[index:])
+			v[index] = value
+			return v, nil
+		}
+		return nil, errPathNotFound
+	})
+}
+
+func pointerRemove(doc interface{}, tokens []string) (interface{}, error) {
+	if len(tokens) == 0 {
+		return nil, errors.New("cannot remove the whole document")
+	}
+	return pointerEdit(doc, tokens, func(parent interface{}, token string) (interface{}, error) {
+		switch v := parent.(type) {
+		case map[string]interface{}:
+			if _, ok := v[token]; !ok {
+				return nil, errPathNotFound
+			}
+			delete(v, token)
+			return v, nil
+		case []interface{}:
+			index, ok := pointerIndex(token, len(v))
+			if !ok {
+				return nil, errPathNotFound
+			}
+			return append(v[:index], v[index+1:]...), nil
+		}
+		return nil, errPathNotFound
+	})
+}
+
+func pointerReplace(doc interface{}, tokens []string, value interface{}) (interface{}, error) {
+	if len(tokens) == 0 {
+		return value, nil
+	}
+	return pointerEdit(doc, tokens, func(parent interface{}, token string) (interface{}, error) {
+		switch v := parent.(type) {
+		case map[string]interface{}:
+			if _, ok := v[token]; !ok {
+				return nil, errPathNotFound
+			}
+			v[token] = value
+			return v, nil
+		case []interface{}:
+			index, ok := pointerIndex(token, len(v))
+			if !ok {
+				return nil, errPathNotFound
+			}
+			v[index] = value
+			return v, nil
+		}
+		return nil, errPathNotFound
+	})
+}
+
+// pointerEdit walks to the parent of the last token and lets edit change it.
+// The result replaces node, since editing an array can reallocate it.
+func pointerEdit(node interface{}, tokens []string, edit func(interface{}, string) (interface{}, error)) (interface{}, error) {
+	if len(tokens) == 1 {
+		return edit(node, tokens[0])
+	}
+	switch v := node.(type) {
+	case map[string]interface{}:
+		child, ok := v[tokens[0]]
+		if !ok {
+			return nil, errPathNotFound
+		}
+		updated, err := pointerEdit(child, tokens[1:], edit)
+		if err != nil {
+			return nil, err
+		}
+		v[tokens[0]] = updated
+		return v, nil
+	case []interface{}:
+		index, ok := pointerIndex(tokens[0], len(v))
+		if !ok {
+			return nil, errPathNotFound
+		}
+		updated, err := pointerEdit(v[index], tokens[1:], edit)
+		if err != nil {
+			return nil, err
+		}
+		v[index] = updated
+		return v, nil
+	}
+	return nil, errPathNotFound
+}
@@ -0,0 +1,262 @@
+package picker
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+func (p *Picker) GetStringPath(path string) string {
+	raw, present := p.lookupPath(path)
+	value, ok := raw.(string)
+	if !ok {
+		p.addPathError(path, TypeString, raw, present)
+		return ""
+	}
+	return value
+}
+
+func (p *Picker) GetIntPath(path string) int64 {
+	raw, present := p.lookupPath(path)
+	value, ok := toInt64(raw)
+	if !ok {
+		p.addPathError(path, TypeInt, raw, present)
+		return 0
+	}
+	return value
+}
+
+func (p *Picker) GetFloatPath(path string) float64 {
+	raw, present := p.lookupPath(path)
+	value, ok := toFloat64(raw)
+	if !ok {
+		p.addPathError(path, TypeFloat, raw, present)
+		return 0
+	}
+	return value
+}
+
+func (p *Picker) GetBoolPath(path string) bool {
+	raw, present := p.lookupPath(path)
+	value, ok := raw.(bool)
+	if !ok {
+		p.addPathError(path, TypeBool, raw, present)
+		return false
+	}
+	return value
+}
+
+// SetPath sets the value at a dot-delimited path, creating objects for
+// missing segments. Array indexes must refer to existing elements. If the
+// path cannot be set, objects created along the way are removed again.
+func (p *Picker) SetPath(path string, value interface{}) error {
+	if p.data == nil {
+		p.data = map[string]interface{}{}
+	}
+	var createdIn map[string]interface{}
+	var createdKey string
+	fail := func(message string) error {
+		if createdIn != nil {
+			delete(createdIn, createdKey)
+		}
+		return errors.New(message)
+	}
+	segments := strings.Split(path, ".")
+	current := p.data
+	for i, segment := range segments {
+		name, indexes, ok := parseSegment(segment)
+		if !ok {
+			return fail("invalid path segment " + segment)
+		}
+		if matched, ok := matchKey(current, name, p.caseInsensitive); ok {
+			name = matched
+		}
+		last := i == len(segments)-1
+		if last && len(indexes) == 0 {
+			current[name] = value
+			return nil
+		}
+		next, exists := current[name]
+		if !exists {
+			if len(indexes) > 0 {
+				return fail("cannot set " + path + ": " + name + " is missing")
+			}
+			created := map[string]interface{}{}
+			if createdIn == nil {
+				createdIn, createdKey = current, name
+			}
+			current[name] = created
+			current = created
+			continue
+		}
+		for j, index := range indexes {
+			arr, ok := next.([]interface{})
+			if !ok {
+				return fail("cannot set " + path + ": expected array at " + segment + ", got " + typeName(next))
+			}
+			if index < 0 {
+				index += len(arr)
+			}
+			if index < 0 || index >= len(arr) {
+				return fail("cannot set " + path + ": index out of range at " + segment)
+			}
+			if last && j == len(indexes)-1 {
+				arr[index] = value
+				return nil
+			}
+			next = arr[index]
+		}
+		obj, ok := next.(map[string]interface{})
+		if !ok {
+			return fail("cannot set " + path + ": expected object at " + segment + ", got " + typeName(next))
+		}
+		current = obj
+	}
+	return nil
+}
+
+// DelPath removes the key at a dot-delimited path and reports whether it
+// existed. The last segment must be a key, not an array index.
+func (p *Picker) DelPath(path string) bool {
+	var parent interface{} = p.data
+	key := path
+	if dot := strings.LastIndexByte(path, '.'); dot != -1 {
+		parent, _ = p.lookupPath(path[:dot])
+		key = path[dot+1:]
+	}
+	obj, ok := parent.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	key, ok = matchKey(obj, key, p.caseInsensitive)
+	if !ok {
+		return false
+	}
+	delete(obj, key)
+	return true
+}
+
+// RequirePaths is RequireKeys for dot-delimited paths. A path that cannot be
+// followed is recorded as invalid.
+func (p *Picker) RequirePaths(paths ...string) *Picker {
+	for _, path := range paths {
+		raw, present := p.lookupPath(path)
+		if _, invalid := raw.(pathError); !present || invalid {
+			p.addPathError(path, "", raw, present)
+		}
+	}
+	return p
+}
+
+// pathError is returned by lookupPath in place of a value when the path
+// cannot be followed, so it never passes a getter's type check.
+type pathError struct {
+	message string
+}
+
+func (p *Picker) addPathError(path string, expected ValueType, raw interface{}, present bool) {
+	if pathErr, ok := raw.(pathError); ok {
+		p.setFieldError(FieldError{
+			Key:      path,
+			Reason:   ErrorInvalid,
+			Expected: expected,
+			Message:  pathErr.message,
+		})
+		return
+	}
+	p.addFieldError(path, expected, raw, present)
+}
+
+// lookupPath reports false only when a key or index along the path does not
+// exist. A value of the wrong type along the way counts as present, so it is
+// reported as invalid rather than missing.
+func (p *Picker) lookupPath(path string) (interface{}, bool) {
+	var current interface{} = p.data
+	for _, segment := range strings.Split(path, ".") {
+		name, indexes, ok := parseSegment(segment)
+		if !ok {
+			return pathError{"invalid path segment " + segment}, true
+		}
+		obj, ok := current.(map[string]interface{})
+		if !ok {
+			return pathError{"expected object before " + segment + ", got " + typeName(current)}, true
+		}
+		current, ok = lookupKey(obj, name, p.caseInsensitive)
+		if !ok {
+			return nil, false
+		}
+		for _, index := range indexes {
+			arr, ok := current.([]interface{})
+			if !ok {
+				return pathError{"expected array at " + segment + ", got " + typeName(current)}, true
+			}
+			if index < 0 {
+				index += len(arr)
+			}
+			if index < 0 || index >= len(arr) {
+				return nil, false
+			}
+			current = arr[index]
+		}
+	}
+	return current, true
+}
+
+// parseSegment splits a segment like "postings[0]" into its key and indexes.
+// Negative indexes count from the end of the array.
+func parseSegment(segment string) (string, []int, bool) {
+	start := strings.IndexByte(segment, '[')
+	if start == -1 {
+		return segment, nil, true
+	}
+	name := segment[:start]
+	indexes := []int{}
+	rest := segment[start:]
+	for len(rest) > 0 {
+		end := strings.IndexByte(rest, ']')
+		if rest[0] != '[' || end == -1 {
+			return "", nil, false
+		}
+		index, err := strconv.Atoi(rest[1:end])
+		if err != nil {
+			return "", nil, false
+		}
+		indexes = append(indexes, index)
+		rest = rest[end+1:]
+	}
+	return name, indexes, true
+}
+
+// Query returns every value matched by a dot-delimited expression, where a
+// "#" segment matches each element of an array, like "body.postings.#.id".
+// A number segment selects a single array element. Segments that do not
+// match are skipped, so a query for missing data returns an empty slice.
+func (p *Picker) Query(expr string) []interface{} {
+	results := []interface{}{}
+	return queryValue(results, p.data, strings.Split(expr, "."), p.caseInsensitive)
+}
+
+func queryValue(results []interface{}, value interface{}, segments []string, fold bool) []interface{} {
+	if len(segments) == 0 {
+		return append(results, value)
+	}
+	segment := segments[0]
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if child, ok := lookupKey(v, segment, fold); ok {
+			return queryValue(results, child, segments[1:], fold)
+		}
+	case []interface{}:
+		if segment == "#" {
+			for _, item := range v {
+				results = queryValue(results, item, segments[1:], fold)
+			}
+			return results
+		}
+		index, err := strconv.Atoi(segment)
+		if err == nil && index >= 0 && index < len(v) {
+			return queryValue(results, v[index], segments[1:], fold)
+		}
+	}
+	return results
+}
@@ -1,17 +1,38 @@
 package picker
 
 import (
+	"bytes"
+	"context"
+	"encoding"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
+	"math"
+	"math/big"
 	"net/http"
+	"net/url"
+	"reflect"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
 var (
-	ErrorMissing = "missing"
-	ErrorInvalid = "invalid"
+	MaxBodySize     int64 = 10 << 20
+	ErrBodyTooLarge       = errors.New("request body too large")
+)
+
+var (
+	ErrorMissing    = "missing"
+	ErrorInvalid    = "invalid"
+	ErrorNull       = "null"
+	ErrorOutOfRange = "out of range"
+	ErrorEmpty      = "empty"
 )
 
 func Pick[T any](data map[string]interface{}, fn func(*Picker) T) (T, error) {
@@ -25,6 +46,15 @@ func Pick[T any](data map[string]interface{}, fn func(*Picker) T) (T, error) {
 	return out, nil
 }
 
+func PickLenient[T any](data map[string]interface{}, fn func(*Picker) T) (T, []FieldError) {
+	inst := newPicker(data)
+	out := fn(inst)
+	if err := inst.Confirm(); err != nil {
+		return out, err.Fields
+	}
+	return out, nil
+}
+
 func PickFromJson[T any](jsonStr string, fn func(*Picker) T) (T, error) {
 	data, err := ParseJson(jsonStr)
 	if err != nil {
@@ -34,6 +64,24 @@ func PickFromJson[T any](jsonStr string, fn func(*Picker) T) (T, error) {
 	return Pick(data, fn)
 }
 
+func PickFromReaderContext[T any](ctx context.Context, r io.Reader, fn func(*Picker) T) (T, error) {
+	data, err := ParseReaderContext(ctx, r)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return Pick(data, fn)
+}
+
+func PickFromBytes[T any](jsonData []byte, fn func(*Picker) T) (T, error) {
+	data, err := ParseBytes(jsonData)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return Pick(data, fn)
+}
+
 func PickFromRequestBody[T any](r *http.Request, fn func(*Picker) T) (T, error) {
 	data, err := ParseRequestBody(r)
 	if err != nil {
@@ -43,28 +91,279 @@ func PickFromRequestBody[T any](r *http.Request, fn func(*Picker) T) (T, error)
 	return Pick(data, fn)
 }
 
+func PickFromRequestBodyLimited[T any](r *http.Request, maxBytes int64, fn func(*Picker) T) (T, error) {
+	data, err := ParseRequestBodyLimited(r, maxBytes)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return Pick(data, fn)
+}
+
+func PickFromReader[T any](r io.Reader, fn func(*Picker) T) (T, error) {
+	data, err := ParseReader(r)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return Pick(data, fn)
+}
+
+func PickArray[T any](data []interface{}, fn func(*Picker) T) ([]T, error) {
+	root := newPicker(map[string]interface{}{})
+	out := make([]T, len(data))
+	for i, item := range data {
+		key := "[" + strconv.Itoa(i) + "]"
+		itemMap, ok := item.(map[string]interface{})
+		if !ok {
+			root.addFieldError(key, TypeObject, item, true)
+			continue
+		}
+		out[i] = fn(newNestedPicker(itemMap, root, key))
+	}
+	err := root.Confirm()
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func PickArrayFromJson[T any](jsonStr string, fn func(*Picker) T) ([]T, error) {
+	data, err := ParseJsonArray(jsonStr)
+	if err != nil {
+		return nil, err
+	}
+	return PickArray(data, fn)
+}
+
+// StreamArray reads the array under a top-level key one element at a time,
+// so the whole array is never held in memory. It stops at the first element
+// that fn rejects or that records errors, and returns that error.
+func StreamArray(r io.Reader, key string, fn func(*Picker) error) error {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	if err := expectDelim(dec, '{'); err != nil {
+		return err
+	}
+	root := newPicker(map[string]interface{}{})
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		if token != key {
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return err
+			}
+			continue
+		}
+		token, err = dec.Token()
+		if err != nil {
+			return err
+		}
+		if token != json.Delim('[') {
+			if _, ok := token.(json.Delim); ok {
+				token = map[string]interface{}{}
+			}
+			root.addFieldError(key, TypeArray, token, true)
+			return root.Confirm()
+		}
+		for i := 0; dec.More(); i++ {
+			var item interface{}
+			if err := dec.Decode(&item); err != nil {
+				return err
+			}
+			itemKey := key + "[" + strconv.Itoa(i) + "]"
+			itemMap, ok := item.(map[string]interface{})
+			if !ok {
+				root.addFieldError(itemKey, TypeObject, item, true)
+				return root.Confirm()
+			}
+			if err := fn(newNestedPicker(itemMap, root, itemKey)); err != nil {
+				return err
+			}
+			if err := root.Confirm(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	root.addFieldError(key, TypeArray, nil, false)
+	return root.Confirm()
+}
+
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if token != delim {
+		return fmt.Errorf("expected %v, got %v", delim, token)
+	}
+	return nil
+}
+
+func PickFromValues[T any](values url.Values, fn func(*Picker) T) (T, error) {
+	return Pick(ParseValues(values), fn)
+}
+
+func PickFromForm[T any](r *http.Request, fn func(*Picker) T) (T, error) {
+	data, err := ParseForm(r)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return Pick(data, fn)
+}
+
 func ParseJson(jsonStr string) (map[string]interface{}, error) {
+	return ParseReader(strings.NewReader(jsonStr))
+}
+
+func ParseBytes(jsonData []byte) (map[string]interface{}, error) {
+	return ParseReader(bytes.NewReader(jsonData))
+}
+
+func ParseRequestBody(r *http.Request) (map[string]interface{}, error) {
+	return ParseRequestBodyLimited(r, MaxBodySize)
+}
+
+// ParseRequestBodyLimited returns ErrBodyTooLarge if the body is longer
+// than maxBytes.
+func ParseRequestBodyLimited(r *http.Request, maxBytes int64) (map[string]interface{}, error) {
+	defer r.Body.Close()
+	return ParseReaderContext(r.Context(), &limitedReader{r: io.LimitReader(r.Body, maxBytes+1), max: maxBytes})
+}
+
+type limitedReader struct {
+	r    io.Reader
+	read int64
+	max  int64
+}
+
+func (lr *limitedReader) Read(buf []byte) (int, error) {
+	n, err := lr.r.Read(buf)
+	lr.read += int64(n)
+	if lr.read > lr.max {
+		return n, ErrBodyTooLarge
+	}
+	return n, err
+}
+
+func ParseReader(r io.Reader) (map[string]interface{}, error) {
 	var data map[string]interface{}
-	err := json.Unmarshal([]byte(jsonStr), &data)
+	err := decodeJson(r, &data)
 	if err != nil {
 		return nil, err
 	}
 	return data, nil
 }
 
-func ParseRequestBody(r *http.Request) (map[string]interface{}, error) {
-	data, err := io.ReadAll(r.Body)
+// ParseReaderContext stops decoding with the context's error once ctx is
+// done. The context is checked before every read from r.
+func ParseReaderContext(ctx context.Context, r io.Reader) (map[string]interface{}, error) {
+	return ParseReader(&contextReader{ctx: ctx, r: r})
+}
+
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (cr *contextReader) Read(buf []byte) (int, error) {
+	if err := cr.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return cr.r.Read(buf)
+}
+
+// ParseValues stores single values as strings and repeated keys as arrays
+// of strings.
+func ParseValues(values url.Values) map[string]interface{} {
+	data := make(map[string]interface{}, len(values))
+	for key, items := range values {
+		if len(items) == 1 {
+			data[key] = items[0]
+			continue
+		}
+		array := make([]interface{}, len(items))
+		for i, item := range items {
+			array[i] = item
+		}
+		data[key] = array
+	}
+	return data
+}
+
+// ParseForm reads the url-encoded body of a POST, PUT or PATCH request.
+// Query parameters are not included.
+func ParseForm(r *http.Request) (map[string]interface{}, error) {
+	err := r.ParseForm()
 	if err != nil {
 		return nil, err
 	}
-	defer r.Body.Close()
-	return ParseJson(string(data))
+	return ParseValues(r.PostForm), nil
+}
+
+func ParseJsonArray(jsonStr string) ([]interface{}, error) {
+	var data []interface{}
+	err := decodeJson(strings.NewReader(jsonStr), &data)
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func decodeJson(r io.Reader, target interface{}) error {
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+	err := decoder.Decode(target)
+	if err != nil {
+		return err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		if err != nil {
+			return err
+		}
+		return errors.New("invalid character after top-level value")
+	}
+	return nil
+}
+
+// MarshalJSON has a pointer receiver, since a Picker holds a mutex and must
+// not be copied. Struct fields must therefore be *Picker, as a Picker value
+// field is marshaled as an empty object.
+func (p *Picker) MarshalJSON() ([]byte, error) {
+	if p.data == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(p.data)
+}
+
+// UnmarshalJSON replaces the data and clears any recorded errors, so a
+// *Picker can be a field of a struct decoded with encoding/json. A JSON null
+// leaves an empty picker.
+func (p *Picker) UnmarshalJSON(jsonData []byte) error {
+	if string(jsonData) == "null" {
+		p.data = map[string]interface{}{}
+		p.ClearErrors()
+		return nil
+	}
+	var data map[string]interface{}
+	err := decodeJson(bytes.NewReader(jsonData), &data)
+	if err != nil {
+		return err
+	}
+	p.data = data
+	p.ClearErrors()
+	return nil
 }
 
 func newPicker(data map[string]interface{}) *Picker {
 	return &Picker{
 		data:         data,
-		errors:       map[string]string{},
+		errors:       map[string]FieldError{},
 		parentPicker: nil,
 		parentKey:    "",
 	}
@@ -72,28 +371,68 @@ func newPicker(data map[string]interface{}) *Picker {
 
 func newNestedPicker(data map[string]interface{}, parent *Picker, key string) *Picker {
 	return &Picker{
-		data:         data,
-		errors:       map[string]string{},
-		parentPicker: parent,
-		parentKey:    key,
+		data:            data,
+		errors:          map[string]FieldError{},
+		parentPicker:    parent,
+		parentKey:       key,
+		caseInsensitive: parent.caseInsensitive,
 	}
 }
 
+// Picker getters and Confirm are safe for concurrent use. Methods that
+// change the data, such as SetPath or Merge, are not.
 type Picker struct {
-	data         map[string]interface{}
-	errors       map[string]string
-	parentPicker *Picker
-	parentKey    string
+	data            map[string]interface{}
+	errors          map[string]FieldError
+	errorsMu        sync.RWMutex
+	parentPicker    *Picker
+	parentKey       string
+	caseInsensitive bool
+}
+
+func (p *Picker) addError(key string, expected ValueType) {
+	value, ok := p.lookup(key)
+	p.addFieldError(key, expected, value, ok)
 }
 
-func (p *Picker) addError(key string) {
-	var reason string
-	if p.HasKey(key) {
-		reason = ErrorInvalid
-	} else {
-		reason = ErrorMissing
+func (p *Picker) addFieldError(key string, expected ValueType, value interface{}, present bool) {
+	if !present {
+		p.setFieldError(FieldError{
+			Key:      key,
+			Reason:   ErrorMissing,
+			Expected: expected,
+			Message:  "missing",
+		})
+		return
+	}
+	if value == nil {
+		p.setFieldError(FieldError{
+			Key:      key,
+			Reason:   ErrorNull,
+			Expected: expected,
+			Got:      "null",
+			Message:  "null not allowed",
+		})
+		return
 	}
-	p.SetError(key, reason)
+	got := typeName(value)
+	p.setFieldError(FieldError{
+		Key:      key,
+		Reason:   ErrorInvalid,
+		Expected: expected,
+		Got:      got,
+		Message:  "expected " + string(expected) + ", got " + got,
+	})
+}
+
+func (p *Picker) addRuleError(key string, reason string, expected ValueType, value interface{}, message string) {
+	p.setFieldError(FieldError{
+		Key:      key,
+		Reason:   reason,
+		Expected: expected,
+		Got:      typeName(value),
+		Message:  message,
+	})
 }
 
 func (p *Picker) SetInvalid(key string) {
@@ -101,136 +440,420 @@ func (p *Picker) SetInvalid(key string) {
 }
 
 func (p *Picker) SetError(key string, reason string) {
-	if p.parentPicker != nil {
-		p.parentPicker.errors[p.parentKey+"."+key] = reason
-	} else {
-		p.errors[key] = reason
+	p.setFieldError(FieldError{Key: key, Reason: reason, Message: reason})
+}
+
+func (p *Picker) setFieldError(fieldErr FieldError) {
+	target := p
+	for target.parentPicker != nil {
+		fieldErr.Key = target.parentKey + "." + fieldErr.Key
+		target = target.parentPicker
 	}
+	target.errorsMu.Lock()
+	defer target.errorsMu.Unlock()
+	target.errors[fieldErr.Key] = fieldErr
 }
 
 func (p *Picker) Confirm() *PickerError {
+	p.errorsMu.RLock()
+	defer p.errorsMu.RUnlock()
 	if len(p.errors) > 0 {
-		return &PickerError{Errors: p.errors}
+		return newPickerError(p.errors)
 	}
 	return nil
 }
 
+// Err returns the errors of the top-level picker, whichever picker in the
+// chain it is called on. Unlike Confirm, it returns a plain nil error when
+// there are no errors.
+func (p *Picker) Err() error {
+	if err := p.root().Confirm(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (p *Picker) ClearErrors() {
+	root := p.root()
+	root.errorsMu.Lock()
+	defer root.errorsMu.Unlock()
+	root.errors = map[string]FieldError{}
+}
+
+// ErrorPaths returns the sorted keys of all recorded errors, as full paths
+// from the top-level picker, whichever picker in the chain it is called on.
+func (p *Picker) ErrorPaths() []string {
+	root := p.root()
+	root.errorsMu.RLock()
+	defer root.errorsMu.RUnlock()
+	paths := make([]string, 0, len(root.errors))
+	for path := range root.errors {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+func (p *Picker) root() *Picker {
+	root := p
+	for root.parentPicker != nil {
+		root = root.parentPicker
+	}
+	return root
+}
+
+// Validate runs each rule against the value of its key and records a failed
+// rule as an invalid field, using the error text as the message. Keys missing
+// from the data are recorded as missing without running the rule. The
+// recorded errors are also returned, sorted by key.
+func (p *Picker) Validate(rules map[string]func(interface{}) error) []FieldError {
+	keys := make([]string, 0, len(rules))
+	for key := range rules {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	failed := []FieldError{}
+	for _, key := range keys {
+		value, ok := p.lookup(key)
+		if !ok {
+			fieldErr := FieldError{Key: key, Reason: ErrorMissing, Message: "missing"}
+			p.setFieldError(fieldErr)
+			failed = append(failed, fieldErr)
+			continue
+		}
+		if err := rules[key](value); err != nil {
+			fieldErr := FieldError{Key: key, Reason: ErrorInvalid, Got: typeName(value), Message: err.Error()}
+			p.setFieldError(fieldErr)
+			failed = append(failed, fieldErr)
+		}
+	}
+	return failed
+}
+
+// SetCaseInsensitive makes key lookups ignore case. An exact match is always
+// preferred. Nested pickers created afterwards inherit the setting.
+func (p *Picker) SetCaseInsensitive(on bool) {
+	p.caseInsensitive = on
+}
+
+func (p *Picker) lookup(key string) (interface{}, bool) {
+	return lookupKey(p.data, key, p.caseInsensitive)
+}
+
+func (p *Picker) get(key string) interface{} {
+	value, _ := p.lookup(key)
+	return value
+}
+
+func lookupKey(data map[string]interface{}, key string, fold bool) (interface{}, bool) {
+	matched, ok := matchKey(data, key, fold)
+	if !ok {
+		return nil, false
+	}
+	return data[matched], true
+}
+
+// matchKey returns the stored spelling of key. Without an exact match, the
+// first of the keys that differ only by case wins, in sorted order, so the
+// result does not depend on map iteration order.
+func matchKey(data map[string]interface{}, key string, fold bool) (string, bool) {
+	if _, ok := data[key]; ok || !fold {
+		return key, ok
+	}
+	matched, found := "", false
+	for dataKey := range data {
+		if strings.EqualFold(dataKey, key) && (!found || dataKey < matched) {
+			matched, found = dataKey, true
+		}
+	}
+	return matched, found
+}
+
 func (p *Picker) HasKey(key string) bool {
-	_, ok := p.data[key]
+	_, ok := p.lookup(key)
+	return ok
+}
+
+// RequireKeys records a missing error for each key that is not present. A
+// null value counts as present.
+func (p *Picker) RequireKeys(keys ...string) *Picker {
+	for _, key := range keys {
+		if _, ok := p.lookup(key); !ok {
+			p.addFieldError(key, "", nil, false)
+		}
+	}
+	return p
+}
+
+// GetAny returns the raw value of key without recording an error.
+func (p *Picker) GetAny(key string) (interface{}, bool) {
+	return p.lookup(key)
+}
+
+func (p *Picker) TypeOf(key string) (ValueType, bool) {
+	value, ok := p.lookup(key)
+	if !ok {
+		return "", false
+	}
+	return valueTypeOf(value), true
+}
+
+func (p *Picker) IsArray(key string) bool {
+	_, ok := p.get(key).([]interface{})
 	return ok
 }
 
+func (p *Picker) IsObject(key string) bool {
+	_, ok := p.get(key).(map[string]interface{})
+	return ok
+}
+
+func (p *Picker) IsNull(key string) bool {
+	value, ok := p.lookup(key)
+	return ok && value == nil
+}
+
+// Len returns the number of elements in an array or keys in an object,
+// without recording an error. It returns false for any other value.
+func (p *Picker) Len(key string) (int, bool) {
+	switch v := p.get(key).(type) {
+	case []interface{}:
+		return len(v), true
+	case map[string]interface{}:
+		return len(v), true
+	}
+	return 0, false
+}
+
 func (p *Picker) Nested(key string) *Picker {
-	value, ok := p.data[key].(map[string]interface{})
+	value, ok := p.get(key).(map[string]interface{})
 	if !ok {
-		p.addError(key)
+		p.addError(key, TypeObject)
 		return newNestedPicker(map[string]interface{}{}, p, key)
 	}
 	return newNestedPicker(value, p, key)
 }
 
+// PickerDecodable is implemented by types that read themselves from a
+// picker, for shapes that are easier to decode by hand.
+type PickerDecodable interface {
+	DecodeFromPicker(*Picker) error
+}
+
+// Decode passes the object at key to target as a nested picker, so errors
+// recorded by the target are keyed by their full path. An error returned by
+// the target is recorded as invalid on key.
+func (p *Picker) Decode(key string, target PickerDecodable) bool {
+	value, ok := p.get(key).(map[string]interface{})
+	if !ok {
+		p.addError(key, TypeObject)
+		return false
+	}
+	if err := target.DecodeFromPicker(newNestedPicker(value, p, key)); err != nil {
+		p.addRuleError(key, ErrorInvalid, TypeObject, value, err.Error())
+		return false
+	}
+	return true
+}
+
 func (p *Picker) NestedArray(key string) *NestedPickerArray {
-	value, ok := p.data[key].([]interface{})
+	value, ok := p.get(key).([]interface{})
 	if !ok {
-		p.addError(key)
-		return newNestedPickerArray(p, make([]*Picker, 0))
+		p.addError(key, TypeArray)
+		return newNestedPickerArray(p, key, make([]*Picker, 0))
 	}
 	pickers := make([]*Picker, len(value))
+	for i, item := range value {
+		itemKey := key + "[" + strconv.Itoa(i) + "]"
+		itemMap, ok := item.(map[string]interface{})
+		if !ok {
+			p.addFieldError(itemKey, TypeObject, item, true)
+			itemMap = map[string]interface{}{}
+		}
+		pickers[i] = newNestedPicker(itemMap, p, itemKey)
+	}
+	return newNestedPickerArray(p, key, pickers)
+}
+
+// NestedArrayLenient is NestedArray for arrays that mix objects with other
+// values. Only the objects become items, and the indexes of the other
+// elements are returned instead of being recorded as errors.
+func (p *Picker) NestedArrayLenient(key string) (*NestedPickerArray, []int) {
+	value, ok := p.get(key).([]interface{})
+	if !ok {
+		p.addError(key, TypeArray)
+		return newNestedPickerArray(p, key, make([]*Picker, 0)), []int{}
+	}
+	pickers := make([]*Picker, 0, len(value))
+	skipped := []int{}
 	for i, item := range value {
 		if itemMap, ok := item.(map[string]interface{}); ok {
-			pickers[i] = newPicker(itemMap)
+			pickers = append(pickers, newNestedPicker(itemMap, p, key+"["+strconv.Itoa(i)+"]"))
 		} else {
-			p.addError(key)
-			pickers[i] = newPicker(map[string]interface{}{})
+			skipped = append(skipped, i)
 		}
 	}
-	return newNestedPickerArray(p, pickers)
+	return newNestedPickerArray(p, key, pickers), skipped
 }
 
 func (p *Picker) GetString(key string) string {
-	value, ok := p.data[key].(string)
+	value, ok := p.get(key).(string)
 	if !ok {
-		p.addError(key)
+		p.addError(key, TypeString)
 		return ""
 	}
 	return value
 }
 
 func (p *Picker) GetStringOr(key string, fallback string) string {
-	value, ok := p.data[key].(string)
+	value, ok := p.get(key).(string)
 	if !ok {
 		return fallback
 	}
 	return value
 }
 
+// GetStringOrUsed is GetStringOr that also reports whether the value came
+// from the data rather than the fallback.
+func (p *Picker) GetStringOrUsed(key string, fallback string) (string, bool) {
+	value, ok := p.get(key).(string)
+	if !ok {
+		return fallback, false
+	}
+	return value, true
+}
+
+func (p *Picker) GetStringNonEmpty(key string) string {
+	value, ok := p.get(key).(string)
+	if !ok {
+		p.addError(key, TypeString)
+		return ""
+	}
+	if value == "" {
+		p.addRuleError(key, ErrorEmpty, TypeString, value, "empty string not allowed")
+	}
+	return value
+}
+
+func (p *Picker) GetStringNonEmptyOr(key string, fallback string) string {
+	value, ok := p.get(key).(string)
+	if !ok || value == "" {
+		return fallback
+	}
+	return value
+}
+
 func (p *Picker) GetInt(key string) int64 {
-	value, ok := p.data[key].(float64)
+	value, ok := toInt64(p.get(key))
 	if !ok {
-		p.addError(key)
+		p.addError(key, TypeInt)
 		return 0
 	}
-	return int64(value)
+	return value
 }
 
 func (p *Picker) GetIntOr(key string, fallback int64) int64 {
-	value, ok := p.data[key].(float64)
+	value, ok := toInt64(p.get(key))
 	if !ok {
 		return fallback
 	}
-	return int64(value)
+	return value
+}
+
+func (p *Picker) GetIntOrUsed(key string, fallback int64) (int64, bool) {
+	value, ok := toInt64(p.get(key))
+	if !ok {
+		return fallback, false
+	}
+	return value, true
+}
+
+func (p *Picker) GetIntStrict(key string) int64 {
+	value, ok := toInt64Strict(p.get(key))
+	if !ok {
+		p.addError(key, TypeInt)
+		return 0
+	}
+	return value
 }
 
 func (p *Picker) GetFloat(key string) float64 {
-	value, ok := p.data[key].(float64)
+	value, ok := toFloat64(p.get(key))
 	if !ok {
-		p.addError(key)
+		p.addError(key, TypeFloat)
 		return 0
 	}
 	return value
 }
 
 func (p *Picker) GetFloatOr(key string, fallback float64) float64 {
-	value, ok := p.data[key].(float64)
+	value, ok := toFloat64(p.get(key))
 	if !ok {
 		return fallback
 	}
 	return value
 }
 
+func (p *Picker) GetFloatOrUsed(key string, fallback float64) (float64, bool) {
+	value, ok := toFloat64(p.get(key))
+	if !ok {
+		return fallback, false
+	}
+	return value, true
+}
+
+// GetNumber is GetFloat that also reports whether a number was read, so a
+// stored zero can be told apart from a failed read.
+func (p *Picker) GetNumber(key string) (float64, bool) {
+	value, ok := toFloat64(p.get(key))
+	if !ok {
+		p.addError(key, TypeFloat)
+		return 0, false
+	}
+	return value, true
+}
+
 func (p *Picker) GetBool(key string) bool {
-	value, ok := p.data[key].(bool)
+	value, ok := p.get(key).(bool)
 	if !ok {
-		p.addError(key)
+		p.addError(key, TypeBool)
 		return false
 	}
 	return value
 }
 
 func (p *Picker) GetBoolOr(key string, fallback bool) bool {
-	value, ok := p.data[key].(bool)
+	value, ok := p.get(key).(bool)
 	if !ok {
 		return fallback
 	}
 	return value
 }
 
+func (p *Picker) GetBoolOrUsed(key string, fallback bool) (bool, bool) {
+	value, ok := p.get(key).(bool)
+	if !ok {
+		return fallback, false
+	}
+	return value, true
+}
+
 func (p *Picker) GetDate(key string) time.Time {
-	value, ok := p.data[key].(string)
+	value, ok := p.get(key).(string)
 	if !ok {
-		p.addError(key)
+		p.addError(key, TypeDate)
 		return time.Time{}
 	}
 	date, ok := parseDate(value)
 	if !ok {
-		p.SetInvalid(key)
+		p.addError(key, TypeDate)
 		return time.Time{}
 	}
 	return date
 }
 
 func (p *Picker) GetDateOr(key string, fallback time.Time) time.Time {
-	value, ok := p.data[key].(string)
+	value, ok := p.get(key).(string)
 	if !ok {
 		return fallback
 	}
@@ -241,17 +864,258 @@ func (p *Picker) GetDateOr(key string, fallback time.Time) time.Time {
 	return date
 }
 
-func (p *Picker) GetObject(key string) map[string]interface{} {
-	value, ok := p.data[key].(map[string]interface{})
+func (p *Picker) GetTime(key string, layout string) time.Time {
+	value, ok := p.get(key).(string)
 	if !ok {
-		p.addError(key)
-		return nil
+		p.addError(key, TypeTime)
+		return time.Time{}
 	}
-	return value
+	parsed, err := time.Parse(layout, value)
+	if err != nil {
+		p.addError(key, TypeTime)
+		return time.Time{}
+	}
+	return parsed
 }
 
-func (p *Picker) GetObjectOr(key string, fallback map[string]interface{}) map[string]interface{} {
-	value, ok := p.data[key].(map[string]interface{})
+// GetTimeMulti tries each layout in order and returns the first successful
+// parse. Without layouts it tries the same formats as GetDate.
+func (p *Picker) GetTimeMulti(key string, layouts ...string) time.Time {
+	if len(layouts) == 0 {
+		layouts = dateFormats
+	}
+	value, ok := p.get(key).(string)
+	if !ok {
+		p.addError(key, TypeTime)
+		return time.Time{}
+	}
+	parsed, ok := parseTimeLayouts(value, layouts)
+	if !ok {
+		p.addError(key, TypeTime)
+		return time.Time{}
+	}
+	return parsed
+}
+
+func (p *Picker) GetTimeOr(key string, layout string, fallback time.Time) time.Time {
+	value, ok := p.get(key).(string)
+	if !ok {
+		return fallback
+	}
+	parsed, err := time.Parse(layout, value)
+	if err != nil {
+		return fallback
+	}
+	return parsed
+}
+
+func (p *Picker) GetTimeUnix(key string) time.Time {
+	whole, fraction, ok := p.getEpoch(key)
+	if !ok {
+		return time.Time{}
+	}
+	return time.Unix(whole, int64(fraction*1e9))
+}
+
+func (p *Picker) GetTimeUnixMillis(key string) time.Time {
+	whole, fraction, ok := p.getEpoch(key)
+	if !ok {
+		return time.Time{}
+	}
+	return time.UnixMilli(whole).Add(time.Duration(fraction * 1e6))
+}
+
+// getEpoch splits an epoch number into its whole and fractional parts. A
+// value outside the int64 range is recorded as out of range.
+func (p *Picker) getEpoch(key string) (int64, float64, bool) {
+	raw := p.get(key)
+	if value, ok := toInt64(raw); ok {
+		return value, 0, true
+	}
+	value, ok := toFloat64(raw)
+	if !ok {
+		p.addError(key, TypeTime)
+		return 0, 0, false
+	}
+	whole, fraction := math.Modf(value)
+	seconds, ok := floatToInt64(whole)
+	if !ok {
+		p.addRuleError(key, ErrorOutOfRange, TypeTime, raw,
+			formatFloat(value)+" is not a valid Unix timestamp")
+		return 0, 0, false
+	}
+	return seconds, fraction, true
+}
+
+func (p *Picker) GetDuration(key string) time.Duration {
+	value, ok := p.get(key).(string)
+	if !ok {
+		p.addError(key, TypeDuration)
+		return 0
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		p.addError(key, TypeDuration)
+		return 0
+	}
+	return duration
+}
+
+func (p *Picker) GetDurationOr(key string, fallback time.Duration) time.Duration {
+	value, ok := p.get(key).(string)
+	if !ok {
+		return fallback
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return fallback
+	}
+	return duration
+}
+
+func (p *Picker) GetBytes(key string) []byte {
+	return p.getEncodedBytes(key, base64.StdEncoding.DecodeString)
+}
+
+// GetBytesURL decodes URL-safe base64 with or without padding, since tokens
+// and signatures usually leave it out.
+func (p *Picker) GetBytesURL(key string) []byte {
+	return p.getEncodedBytes(key, decodeBase64URL)
+}
+
+func (p *Picker) GetBytesOr(key string, fallback []byte) []byte {
+	value, ok := p.get(key).(string)
+	if !ok {
+		return fallback
+	}
+	decoded, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return fallback
+	}
+	return decoded
+}
+
+func (p *Picker) getEncodedBytes(key string, decode func(string) ([]byte, error)) []byte {
+	value, ok := p.get(key).(string)
+	if !ok {
+		p.addError(key, TypeBytes)
+		return nil
+	}
+	decoded, err := decode(value)
+	if err != nil {
+		p.addError(key, TypeBytes)
+		return nil
+	}
+	return decoded
+}
+
+func decodeBase64URL(value string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(value, "="))
+}
+
+func (p *Picker) GetUUID(key string) string {
+	value, ok := p.get(key).(string)
+	if !ok {
+		p.addError(key, TypeUUID)
+		return ""
+	}
+	uuid, ok := parseUUID(value)
+	if !ok {
+		p.addError(key, TypeUUID)
+		return ""
+	}
+	return uuid
+}
+
+func (p *Picker) GetUUIDOr(key string, fallback string) string {
+	value, ok := p.get(key).(string)
+	if !ok {
+		return fallback
+	}
+	uuid, ok := parseUUID(value)
+	if !ok {
+		return fallback
+	}
+	return uuid
+}
+
+func (p *Picker) GetEnum(key string, allowed ...string) string {
+	return p.getEnum(key, allowed, false)
+}
+
+func (p *Picker) GetEnumFold(key string, allowed ...string) string {
+	return p.getEnum(key, allowed, true)
+}
+
+func (p *Picker) getEnum(key string, allowed []string, fold bool) string {
+	value, ok := p.get(key).(string)
+	if !ok {
+		p.addError(key, TypeString)
+		return ""
+	}
+	for _, option := range allowed {
+		if value == option || (fold && strings.EqualFold(value, option)) {
+			return option
+		}
+	}
+	p.addRuleError(key, ErrorInvalid, TypeString, value,
+		strconv.Quote(value)+" is not one of: "+strings.Join(allowed, ", "))
+	return ""
+}
+
+func (p *Picker) GetStringMatching(key string, pattern *regexp.Regexp) string {
+	value, ok := p.get(key).(string)
+	if !ok {
+		p.addError(key, TypeString)
+		return ""
+	}
+	if !pattern.MatchString(value) {
+		p.addRuleError(key, ErrorInvalid, TypeString, value,
+			strconv.Quote(value)+" does not match "+pattern.String())
+		return ""
+	}
+	return value
+}
+
+func (p *Picker) GetIntInRange(key string, min int64, max int64) int64 {
+	value, ok := toInt64(p.get(key))
+	if !ok {
+		p.addError(key, TypeInt)
+		return 0
+	}
+	if value < min || value > max {
+		p.addRuleError(key, ErrorOutOfRange, TypeInt, p.get(key), strconv.FormatInt(value, 10)+
+			" is not between "+strconv.FormatInt(min, 10)+" and "+strconv.FormatInt(max, 10))
+		return 0
+	}
+	return value
+}
+
+func (p *Picker) GetFloatInRange(key string, min float64, max float64) float64 {
+	value, ok := toFloat64(p.get(key))
+	if !ok {
+		p.addError(key, TypeFloat)
+		return 0
+	}
+	if value < min || value > max {
+		p.addRuleError(key, ErrorOutOfRange, TypeFloat, p.get(key), formatFloat(value)+
+			" is not between "+formatFloat(min)+" and "+formatFloat(max))
+		return 0
+	}
+	return value
+}
+
+func (p *Picker) GetObject(key string) map[string]interface{} {
+	value, ok := p.get(key).(map[string]interface{})
+	if !ok {
+		p.addError(key, TypeObject)
+		return nil
+	}
+	return value
+}
+
+func (p *Picker) GetObjectOr(key string, fallback map[string]interface{}) map[string]interface{} {
+	value, ok := p.get(key).(map[string]interface{})
 	if !ok {
 		return fallback
 	}
@@ -259,33 +1123,726 @@ func (p *Picker) GetObjectOr(key string, fallback map[string]interface{}) map[st
 }
 
 func (p *Picker) GetArray(key string) []interface{} {
-	value, ok := p.data[key].([]interface{})
+	value, ok := p.get(key).([]interface{})
 	if !ok {
-		p.addError(key)
+		p.addError(key, TypeArray)
 		return nil
 	}
 	return value
 }
 
 func (p *Picker) GetArrayOr(key string, fallback []interface{}) []interface{} {
-	value, ok := p.data[key].([]interface{})
+	value, ok := p.get(key).([]interface{})
+	if !ok {
+		return fallback
+	}
+	return value
+}
+
+func (p *Picker) GetBigInt(key string) *big.Int {
+	value, ok := toBigInt(p.get(key))
+	if !ok {
+		p.addError(key, TypeBigInt)
+		return nil
+	}
+	return value
+}
+
+func (p *Picker) GetBigIntOr(key string, fallback *big.Int) *big.Int {
+	value, ok := toBigInt(p.get(key))
+	if !ok {
+		return fallback
+	}
+	return value
+}
+
+func (p *Picker) GetBigFloat(key string) *big.Float {
+	value, ok := toBigFloat(p.get(key))
+	if !ok {
+		p.addError(key, TypeBigFloat)
+		return nil
+	}
+	return value
+}
+
+func (p *Picker) GetBigFloatOr(key string, fallback *big.Float) *big.Float {
+	value, ok := toBigFloat(p.get(key))
+	if !ok {
+		return fallback
+	}
+	return value
+}
+
+func (p *Picker) GetBigRat(key string) *big.Rat {
+	value, ok := toBigRat(p.get(key))
+	if !ok {
+		p.addError(key, TypeBigRat)
+		return nil
+	}
+	return value
+}
+
+func (p *Picker) GetBigRatOr(key string, fallback *big.Rat) *big.Rat {
+	value, ok := toBigRat(p.get(key))
+	if !ok {
+		return fallback
+	}
+	return value
+}
+
+// The String getters parse numbers and booleans that arrive as strings,
+// as they do in query parameters and form data.
+
+func (p *Picker) GetIntString(key string) int64 {
+	value, ok := p.get(key).(string)
+	if !ok {
+		p.addError(key, TypeInt)
+		return 0
+	}
+	parsed, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		p.addError(key, TypeInt)
+		return 0
+	}
+	return parsed
+}
+
+func (p *Picker) GetFloatString(key string) float64 {
+	value, ok := p.get(key).(string)
+	if !ok {
+		p.addError(key, TypeFloat)
+		return 0
+	}
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		p.addError(key, TypeFloat)
+		return 0
+	}
+	return parsed
+}
+
+func (p *Picker) GetBoolString(key string) bool {
+	value, ok := p.get(key).(string)
+	if !ok {
+		p.addError(key, TypeBool)
+		return false
+	}
+	switch strings.ToLower(value) {
+	case "yes":
+		return true
+	case "no":
+		return false
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		p.addError(key, TypeBool)
+		return false
+	}
+	return parsed
+}
+
+// GetBoolLoose accepts a bool, the numbers 0 and 1, or the strings "true",
+// "false", "yes", "no", "1" and "0" in any case.
+func (p *Picker) GetBoolLoose(key string) bool {
+	value := p.get(key)
+	switch v := value.(type) {
+	case bool:
+		return v
+	case string:
+		switch strings.ToLower(v) {
+		case "true", "yes", "1":
+			return true
+		case "false", "no", "0":
+			return false
+		}
+	default:
+		if n, ok := toInt64(v); ok && (n == 0 || n == 1) {
+			return n == 1
+		}
+	}
+	p.addError(key, TypeBool)
+	return false
+}
+
+// The CSV getters split a comma-separated string like "1, 2, 3" and trim
+// each element. An empty string is an empty list.
+
+func (p *Picker) GetStringCSV(key string) []string {
+	value, ok := p.get(key).(string)
+	if !ok {
+		p.addError(key, TypeString)
+		return []string{}
+	}
+	return splitCSV(value)
+}
+
+func (p *Picker) GetIntCSV(key string) []int64 {
+	value, ok := p.get(key).(string)
+	if !ok {
+		p.addError(key, TypeString)
+		return []int64{}
+	}
+	parts := splitCSV(value)
+	result := make([]int64, len(parts))
+	for i, part := range parts {
+		parsed, err := strconv.ParseInt(part, 10, 64)
+		if err != nil {
+			p.addRuleError(key, ErrorInvalid, TypeInt, value,
+				strconv.Quote(part)+" at index "+strconv.Itoa(i)+" is not an int")
+			return []int64{}
+		}
+		result[i] = parsed
+	}
+	return result
+}
+
+func splitCSV(value string) []string {
+	if strings.TrimSpace(value) == "" {
+		return []string{}
+	}
+	parts := strings.Split(value, ",")
+	for i, part := range parts {
+		parts[i] = strings.TrimSpace(part)
+	}
+	return parts
+}
+
+func (p *Picker) GetStringSlice(key string) []string {
+	return GetTypedArray[string](p, key)
+}
+
+func (p *Picker) GetIntSlice(key string) []int64 {
+	return GetTypedArray[int64](p, key)
+}
+
+func (p *Picker) GetFloatSlice(key string) []float64 {
+	return GetTypedArray[float64](p, key)
+}
+
+func (p *Picker) GetBoolSlice(key string) []bool {
+	return GetTypedArray[bool](p, key)
+}
+
+func (p *Picker) GetStringSliceOr(key string, fallback []string) []string {
+	return typedArrayOr(p, key, fallback)
+}
+
+func (p *Picker) GetIntSliceOr(key string, fallback []int64) []int64 {
+	return typedArrayOr(p, key, fallback)
+}
+
+func (p *Picker) GetFloatSliceOr(key string, fallback []float64) []float64 {
+	return typedArrayOr(p, key, fallback)
+}
+
+func (p *Picker) GetBoolSliceOr(key string, fallback []bool) []bool {
+	return typedArrayOr(p, key, fallback)
+}
+
+func typedArrayOr[T any](p *Picker, key string, fallback []T) []T {
+	value, ok := p.get(key).([]interface{})
 	if !ok {
 		return fallback
 	}
+	result := make([]T, len(value))
+	for i, item := range value {
+		if result[i], ok = convert[T](item); !ok {
+			return fallback
+		}
+	}
+	return result
+}
+
+// GetText decodes a string value into any type that implements
+// encoding.TextUnmarshaler, such as net.IP or a custom enum. An error from
+// UnmarshalText is recorded as invalid with its text as the message.
+func (p *Picker) GetText(key string, target encoding.TextUnmarshaler) bool {
+	value, ok := p.get(key).(string)
+	if !ok {
+		p.addError(key, TypeString)
+		return false
+	}
+	if err := target.UnmarshalText([]byte(value)); err != nil {
+		p.addRuleError(key, ErrorInvalid, TypeString, value, err.Error())
+		return false
+	}
+	return true
+}
+
+// nullable
+
+// The Ptr getters return nil for a missing or null key without recording an
+// error. A present value of the wrong type is still invalid.
+
+func (p *Picker) GetStringPtr(key string) *string {
+	if p.get(key) == nil {
+		return nil
+	}
+	value, ok := p.get(key).(string)
+	if !ok {
+		p.addError(key, TypeString)
+		return nil
+	}
+	return &value
+}
+
+func (p *Picker) GetIntPtr(key string) *int64 {
+	if p.get(key) == nil {
+		return nil
+	}
+	value, ok := toInt64(p.get(key))
+	if !ok {
+		p.addError(key, TypeInt)
+		return nil
+	}
+	return &value
+}
+
+func (p *Picker) GetFloatPtr(key string) *float64 {
+	if p.get(key) == nil {
+		return nil
+	}
+	value, ok := toFloat64(p.get(key))
+	if !ok {
+		p.addError(key, TypeFloat)
+		return nil
+	}
+	return &value
+}
+
+func (p *Picker) GetBoolPtr(key string) *bool {
+	if p.get(key) == nil {
+		return nil
+	}
+	value, ok := p.get(key).(bool)
+	if !ok {
+		p.addError(key, TypeBool)
+		return nil
+	}
+	return &value
+}
+
+// one of
+
+// The OneOf getters read the first of several keys that is present and
+// return which key matched, for fields that were renamed upstream. If none
+// is present, a missing error is recorded under the names joined by "|".
+
+func (p *Picker) GetStringOneOf(keys ...string) (string, string) {
+	key, raw, ok := p.lookupOneOf(keys, TypeString)
+	if !ok {
+		return "", ""
+	}
+	value, ok := raw.(string)
+	if !ok {
+		p.addFieldError(key, TypeString, raw, true)
+		return "", key
+	}
+	return value, key
+}
+
+func (p *Picker) GetIntOneOf(keys ...string) (int64, string) {
+	key, raw, ok := p.lookupOneOf(keys, TypeInt)
+	if !ok {
+		return 0, ""
+	}
+	value, ok := toInt64(raw)
+	if !ok {
+		p.addFieldError(key, TypeInt, raw, true)
+		return 0, key
+	}
+	return value, key
+}
+
+func (p *Picker) GetFloatOneOf(keys ...string) (float64, string) {
+	key, raw, ok := p.lookupOneOf(keys, TypeFloat)
+	if !ok {
+		return 0, ""
+	}
+	value, ok := toFloat64(raw)
+	if !ok {
+		p.addFieldError(key, TypeFloat, raw, true)
+		return 0, key
+	}
+	return value, key
+}
+
+func (p *Picker) GetBoolOneOf(keys ...string) (bool, string) {
+	key, raw, ok := p.lookupOneOf(keys, TypeBool)
+	if !ok {
+		return false, ""
+	}
+	value, ok := raw.(bool)
+	if !ok {
+		p.addFieldError(key, TypeBool, raw, true)
+		return false, key
+	}
+	return value, key
+}
+
+func (p *Picker) lookupOneOf(keys []string, expected ValueType) (string, interface{}, bool) {
+	for _, key := range keys {
+		if value, ok := p.lookup(key); ok {
+			return key, value, true
+		}
+	}
+	p.addFieldError(strings.Join(keys, "|"), expected, nil, false)
+	return "", nil, false
+}
+
+// must
+
+// The Must getters panic instead of recording an error. They are meant for
+// trusted payloads and tests, where a malformed value is a programming error.
+
+func (p *Picker) MustGetString(key string) string {
+	value, ok := p.get(key).(string)
+	if !ok {
+		p.mustPanic(key, TypeString)
+	}
+	return value
+}
+
+func (p *Picker) MustGetInt(key string) int64 {
+	value, ok := toInt64(p.get(key))
+	if !ok {
+		p.mustPanic(key, TypeInt)
+	}
+	return value
+}
+
+func (p *Picker) MustGetFloat(key string) float64 {
+	value, ok := toFloat64(p.get(key))
+	if !ok {
+		p.mustPanic(key, TypeFloat)
+	}
 	return value
 }
 
+func (p *Picker) MustGetBool(key string) bool {
+	value, ok := p.get(key).(bool)
+	if !ok {
+		p.mustPanic(key, TypeBool)
+	}
+	return value
+}
+
+func (p *Picker) mustPanic(key string, expected ValueType) {
+	value, ok := p.lookup(key)
+	if !ok {
+		panic("picker: key " + strconv.Quote(key) + " is missing, expected " + string(expected))
+	}
+	panic("picker: key " + strconv.Quote(key) + ": expected " + string(expected) + ", got " + typeName(value))
+}
+
+// numbers
+
+// JSON numbers are decoded as json.Number, but data passed to Pick directly
+// may hold float64 or Go integers, so the numeric getters accept all of them.
+
+func toInt64(value interface{}) (int64, bool) {
+	switch v := value.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i, true
+		}
+		f, err := v.Float64()
+		if err != nil {
+			return 0, false
+		}
+		return floatToInt64(f)
+	case float64:
+		return floatToInt64(v)
+	case int64:
+		return v, true
+	case int:
+		return int64(v), true
+	}
+	return 0, false
+}
+
+// toInt64Strict only accepts integer literals, so 30.0 and 3e1 are rejected.
+func toInt64Strict(value interface{}) (int64, bool) {
+	switch v := value.(type) {
+	case json.Number:
+		i, err := v.Int64()
+		return i, err == nil
+	case int64:
+		return v, true
+	case int:
+		return int64(v), true
+	}
+	return 0, false
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+func toUint64(value interface{}) (uint64, bool) {
+	switch v := value.(type) {
+	case json.Number:
+		if u, err := strconv.ParseUint(string(v), 10, 64); err == nil {
+			return u, true
+		}
+		f, err := v.Float64()
+		if err != nil {
+			return 0, false
+		}
+		return floatToUint64(f)
+	case float64:
+		return floatToUint64(v)
+	case int64:
+		return uint64(v), v >= 0
+	case int:
+		return uint64(v), v >= 0
+	}
+	return 0, false
+}
+
+// toSigned and toUnsigned convert to narrower integer types and fail on
+// overflow instead of wrapping.
+
+func toSigned[T int | int8 | int16 | int32](value interface{}) (T, bool) {
+	i, ok := toInt64(value)
+	if !ok || int64(T(i)) != i {
+		return 0, false
+	}
+	return T(i), true
+}
+
+func toUnsigned[T uint | uint8 | uint16 | uint32 | uint64](value interface{}) (T, bool) {
+	u, ok := toUint64(value)
+	if !ok || uint64(T(u)) != u {
+		return 0, false
+	}
+	return T(u), true
+}
+
+func floatToUint64(value float64) (uint64, bool) {
+	if value != math.Trunc(value) || value < 0 || value >= math.MaxUint64 {
+		return 0, false
+	}
+	return uint64(value), true
+}
+
+func floatToInt64(value float64) (int64, bool) {
+	if value != math.Trunc(value) || value < math.MinInt64 || value >= math.MaxInt64 {
+		return 0, false
+	}
+	return int64(value), true
+}
+
+func toFloat64(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case json.Number:
+		f, err := v.Float64()
+		if err != nil {
+			return 0, false
+		}
+		return f, true
+	case float64:
+		return v, true
+	case int64:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	}
+	return 0, false
+}
+
+// Big numbers are read from json.Number or numeric strings so values beyond
+// the range of int64 and float64 survive intact.
+
+func toBigInt(value interface{}) (*big.Int, bool) {
+	switch v := value.(type) {
+	case *big.Int:
+		return v, true
+	case json.Number:
+		return parseBigInt(string(v))
+	case string:
+		return parseBigInt(v)
+	case int64:
+		return big.NewInt(v), true
+	case int:
+		return big.NewInt(int64(v)), true
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) || v != math.Trunc(v) {
+			return nil, false
+		}
+		i, _ := big.NewFloat(v).Int(nil)
+		return i, true
+	}
+	return nil, false
+}
+
+// parseBigInt also accepts integral values written with a fraction or an
+// exponent, like "1e3" or "12.0", the same as GetInt does.
+func parseBigInt(value string) (*big.Int, bool) {
+	if i, ok := new(big.Int).SetString(value, 10); ok {
+		return i, true
+	}
+	r, ok := new(big.Rat).SetString(value)
+	if !ok || !r.IsInt() {
+		return nil, false
+	}
+	return new(big.Int).Set(r.Num()), true
+}
+
+func toBigFloat(value interface{}) (*big.Float, bool) {
+	switch v := value.(type) {
+	case *big.Float:
+		return v, true
+	case json.Number:
+		return new(big.Float).SetString(string(v))
+	case string:
+		return new(big.Float).SetString(v)
+	case int64:
+		return new(big.Float).SetInt64(v), true
+	case int:
+		return new(big.Float).SetInt64(int64(v)), true
+	case float64:
+		if math.IsNaN(v) {
+			return nil, false
+		}
+		return big.NewFloat(v), true
+	}
+	return nil, false
+}
+
+func toBigRat(value interface{}) (*big.Rat, bool) {
+	switch v := value.(type) {
+	case *big.Rat:
+		return v, true
+	case *big.Int:
+		return new(big.Rat).SetInt(v), true
+	case *big.Float:
+		r, _ := v.Rat(nil)
+		return r, r != nil
+	case json.Number:
+		return new(big.Rat).SetString(string(v))
+	case string:
+		return new(big.Rat).SetString(v)
+	case int64:
+		return new(big.Rat).SetInt64(v), true
+	case int:
+		return new(big.Rat).SetInt64(int64(v)), true
+	case float64:
+		r := new(big.Rat).SetFloat64(v)
+		return r, r != nil
+	}
+	return nil, false
+}
+
+func convert[T any](value interface{}) (T, bool) {
+	var out T
+	var ok bool
+	switch target := any(&out).(type) {
+	case *int64:
+		*target, ok = toInt64(value)
+	case *int:
+		*target, ok = toSigned[int](value)
+	case *int32:
+		*target, ok = toSigned[int32](value)
+	case *int16:
+		*target, ok = toSigned[int16](value)
+	case *int8:
+		*target, ok = toSigned[int8](value)
+	case *uint64:
+		*target, ok = toUnsigned[uint64](value)
+	case *uint:
+		*target, ok = toUnsigned[uint](value)
+	case *uint32:
+		*target, ok = toUnsigned[uint32](value)
+	case *uint16:
+		*target, ok = toUnsigned[uint16](value)
+	case *uint8:
+		*target, ok = toUnsigned[uint8](value)
+	case *float64:
+		*target, ok = toFloat64(value)
+	case **big.Int:
+		*target, ok = toBigInt(value)
+	case **big.Float:
+		*target, ok = toBigFloat(value)
+	case **big.Rat:
+		*target, ok = toBigRat(value)
+	default:
+		out, ok = value.(T)
+	}
+	return out, ok
+}
+
+func valueTypeOf(value interface{}) ValueType {
+	switch v := value.(type) {
+	case nil:
+		return TypeNull
+	case string:
+		return TypeString
+	case json.Number:
+		if _, err := v.Int64(); err == nil {
+			return TypeInt
+		}
+		return TypeFloat
+	case float64:
+		return TypeFloat
+	case int64, int:
+		return TypeInt
+	case bool:
+		return TypeBool
+	case *big.Int:
+		return TypeBigInt
+	case *big.Float:
+		return TypeBigFloat
+	case *big.Rat:
+		return TypeBigRat
+	case map[string]interface{}:
+		return TypeObject
+	case []interface{}:
+		return TypeArray
+	}
+	return ValueType(typeName(value))
+}
+
+func expectedType[T any]() ValueType {
+	var zero T
+	switch any(zero).(type) {
+	case string:
+		return TypeString
+	case int64:
+		return TypeInt
+	case float64:
+		return TypeFloat
+	case bool:
+		return TypeBool
+	case *big.Int:
+		return TypeBigInt
+	case *big.Float:
+		return TypeBigFloat
+	case *big.Rat:
+		return TypeBigRat
+	case map[string]interface{}:
+		return TypeObject
+	case []interface{}:
+		return TypeArray
+	}
+	return ValueType(reflect.TypeFor[T]().String())
+}
+
 // date
 
+var dateFormats = []string{
+	time.RFC3339,          // "2025-01-13T10:30:00Z"
+	"2006-01-02",          // "2025-01-13"
+	"2006-01-02T15:04:05", // "2025-01-13T10:30:00"
+}
+
 func parseDate(value string) (time.Time, bool) {
-	formats := []string{
-		time.RFC3339,          // "2025-01-13T10:30:00Z"
-		"2006-01-02",          // "2025-01-13"
-		"2006-01-02T15:04:05", // "2025-01-13T10:30:00"
-	}
+	return parseTimeLayouts(value, dateFormats)
+}
 
-	for _, format := range formats {
-		if parsedTime, err := time.Parse(format, value); err == nil {
+func parseTimeLayouts(value string, layouts []string) (time.Time, bool) {
+	for _, layout := range layouts {
+		if parsedTime, err := time.Parse(layout, value); err == nil {
 			return parsedTime, true
 		}
 	}
@@ -293,10 +1850,82 @@ func parseDate(value string) (time.Time, bool) {
 	return time.Time{}, false
 }
 
+// uuid
+
+// parseUUID validates the 8-4-4-4-12 hex form and returns it in lower case.
+func parseUUID(value string) (string, bool) {
+	if len(value) != 36 {
+		return "", false
+	}
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		switch {
+		case i == 8 || i == 13 || i == 18 || i == 23:
+			if c != '-' {
+				return "", false
+			}
+		case c >= '0' && c <= '9', c >= 'a' && c <= 'f', c >= 'A' && c <= 'F':
+		default:
+			return "", false
+		}
+	}
+	return strings.ToLower(value), true
+}
+
 // errors
 
+type ValueType string
+
+const (
+	TypeString   ValueType = "string"
+	TypeInt      ValueType = "int"
+	TypeFloat    ValueType = "float"
+	TypeBool     ValueType = "bool"
+	TypeBigInt   ValueType = "big int"
+	TypeBigFloat ValueType = "big float"
+	TypeBigRat   ValueType = "big rat"
+	TypeDate     ValueType = "date"
+	TypeTime     ValueType = "time"
+	TypeDuration ValueType = "duration"
+	TypeBytes    ValueType = "base64"
+	TypeUUID     ValueType = "uuid"
+	TypeObject   ValueType = "object"
+	TypeArray    ValueType = "array"
+	TypeNull     ValueType = "null"
+)
+
+// FieldError describes a single validation failure. Reason is the value
+// reported by Detail, Message is a readable description of what went wrong.
+type FieldError struct {
+	Key      string
+	Reason   string
+	Expected ValueType
+	Got      string
+	Message  string
+}
+
+func (fe FieldError) Error() string {
+	return fe.Key + ": " + fe.Message
+}
+
 type PickerError struct {
 	Errors map[string]string
+	Fields []FieldError
+}
+
+func newPickerError(fieldErrs map[string]FieldError) *PickerError {
+	pe := &PickerError{
+		Errors: make(map[string]string, len(fieldErrs)),
+		Fields: make([]FieldError, 0, len(fieldErrs)),
+	}
+	for key, fieldErr := range fieldErrs {
+		pe.Errors[key] = fieldErr.Reason
+		pe.Fields = append(pe.Fields, fieldErr)
+	}
+	sort.Slice(pe.Fields, func(i, j int) bool {
+		return pe.Fields[i].Key < pe.Fields[j].Key
+	})
+	return pe
 }
 
 func (pe *PickerError) Error() string {
@@ -319,6 +1948,20 @@ func Detail(err error) map[string]string {
 	return map[string]string{}
 }
 
+func FieldErrors(err error) []FieldError {
+	if pickerErr, ok := err.(*PickerError); ok {
+		return pickerErr.Fields
+	}
+	return []FieldError{}
+}
+
+func typeName(value interface{}) string {
+	if value == nil {
+		return "null"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
 // array
 
 type NestedPickerArray struct {
@@ -327,21 +1970,78 @@ type NestedPickerArray struct {
 	Items     []*Picker
 }
 
-func newNestedPickerArray(parent *Picker, items []*Picker) *NestedPickerArray {
+func newNestedPickerArray(parent *Picker, key string, items []*Picker) *NestedPickerArray {
 	return &NestedPickerArray{
-		parent: parent,
-		Items:  items,
+		nestedKey: key,
+		parent:    parent,
+		Items:     items,
 	}
 }
 
 func (npa *NestedPickerArray) At(index int) *Picker {
 	if index < 0 || index >= len(npa.Items) {
-		npa.parent.addError(npa.nestedKey + "[" + strconv.Itoa(index) + "]")
+		npa.parent.addError(npa.nestedKey+"["+strconv.Itoa(index)+"]", TypeObject)
 		return newPicker(map[string]interface{}{})
 	}
 	return npa.Items[index]
 }
 
+// First and Last record an empty error under the array's key when there are
+// no items.
+
+func (npa *NestedPickerArray) First() *Picker {
+	if len(npa.Items) == 0 {
+		return npa.addEmptyError()
+	}
+	return npa.Items[0]
+}
+
+func (npa *NestedPickerArray) Last() *Picker {
+	if len(npa.Items) == 0 {
+		return npa.addEmptyError()
+	}
+	return npa.Items[len(npa.Items)-1]
+}
+
+func (npa *NestedPickerArray) addEmptyError() *Picker {
+	npa.parent.addRuleError(npa.nestedKey, ErrorEmpty, TypeArray, npa.parent.get(npa.nestedKey), "empty array")
+	return newPicker(map[string]interface{}{})
+}
+
+func (npa *NestedPickerArray) Len() int {
+	return len(npa.Items)
+}
+
+func (npa *NestedPickerArray) ForEach(fn func(int, *Picker)) {
+	for i, item := range npa.Items {
+		fn(i, item)
+	}
+}
+
+func (npa *NestedPickerArray) Map(fn func(*Picker) interface{}) []interface{} {
+	return Map(npa, fn)
+}
+
+func (npa *NestedPickerArray) Filter(fn func(*Picker) bool) *NestedPickerArray {
+	items := make([]*Picker, 0, len(npa.Items))
+	for _, item := range npa.Items {
+		if fn(item) {
+			items = append(items, item)
+		}
+	}
+	filtered := newNestedPickerArray(npa.parent, npa.nestedKey, items)
+	return filtered
+}
+
+func (npa *NestedPickerArray) Find(fn func(*Picker) bool) (*Picker, int) {
+	for i, item := range npa.Items {
+		if fn(item) {
+			return item, i
+		}
+	}
+	return nil, -1
+}
+
 func Map[T any](npa *NestedPickerArray, fn func(*Picker) T) []T {
 	result := make([]T, len(npa.Items))
 	for i, item := range npa.Items {
@@ -351,21 +2051,111 @@ func Map[T any](npa *NestedPickerArray, fn func(*Picker) T) []T {
 }
 
 func GetTypedArray[T any](p *Picker, key string) []T {
-	value, ok := p.data[key].([]interface{})
+	value, ok := p.get(key).([]interface{})
 	if !ok {
-		p.addError(key)
+		p.addError(key, TypeArray)
 		return []T{}
 	}
 
 	result := make([]T, 0, len(value))
-	for _, item := range value {
-		if typedItem, ok := item.(T); ok {
+	for i, item := range value {
+		if typedItem, ok := convert[T](item); ok {
 			result = append(result, typedItem)
 		} else {
-			p.addError(key)
+			p.addFieldError(key+"["+strconv.Itoa(i)+"]", expectedType[T](), item, true)
 			return []T{}
 		}
 	}
 
 	return result
 }
+
+// GetTypedArrayPartial converts what it can instead of giving up on the first
+// bad element. Elements that cannot be converted are left as the zero value,
+// recorded as errors keyed by position like "nums[2]" and counted.
+func GetTypedArrayPartial[T any](p *Picker, key string) ([]T, int) {
+	value, ok := p.get(key).([]interface{})
+	if !ok {
+		p.addError(key, TypeArray)
+		return []T{}, 0
+	}
+
+	result := make([]T, len(value))
+	failed := 0
+	for i, item := range value {
+		typedItem, ok := convert[T](item)
+		if !ok {
+			p.addFieldError(key+"["+strconv.Itoa(i)+"]", expectedType[T](), item, true)
+			failed++
+			continue
+		}
+		result[i] = typedItem
+	}
+	return result, failed
+}
+
+func GetTypedMatrix[T any](p *Picker, key string) [][]T {
+	value, ok := p.get(key).([]interface{})
+	if !ok {
+		p.addError(key, TypeArray)
+		return [][]T{}
+	}
+
+	result := make([][]T, len(value))
+	failed := false
+	for i, row := range value {
+		rowKey := key + "[" + strconv.Itoa(i) + "]"
+		items, ok := row.([]interface{})
+		if !ok {
+			p.addFieldError(rowKey, TypeArray, row, true)
+			failed = true
+			continue
+		}
+		result[i] = make([]T, len(items))
+		for j, item := range items {
+			if typedItem, ok := convert[T](item); ok {
+				result[i][j] = typedItem
+			} else {
+				p.addFieldError(rowKey+"["+strconv.Itoa(j)+"]", expectedType[T](), item, true)
+				failed = true
+			}
+		}
+	}
+	if failed {
+		return [][]T{}
+	}
+
+	return result
+}
+
+func GetTypedMap[T any](p *Picker, key string) map[string]T {
+	value, ok := p.get(key).(map[string]interface{})
+	if !ok {
+		p.addError(key, TypeObject)
+		return map[string]T{}
+	}
+
+	result := make(map[string]T, len(value))
+	failed := false
+	for itemKey, item := range value {
+		if typedItem, ok := convert[T](item); ok {
+			result[itemKey] = typedItem
+		} else {
+			p.addFieldError(key+"."+itemKey, expectedType[T](), item, true)
+			failed = true
+		}
+	}
+	if failed {
+		return map[string]T{}
+	}
+
+	return result
+}
+
+func Get[T any](p *Picker, key string) (T, bool) {
+	value, ok := convert[T](p.get(key))
+	if !ok {
+		p.addError(key, expectedType[T]())
+	}
+	return value, ok
+}
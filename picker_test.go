@@ -0,0 +1,128 @@
+package picker
+
+import "testing"
+
+func TestNestedArrayErrorKeys(t *testing.T) {
+	jsonStr := `{"users": [{"name": "John"}, {"age": 30}]}`
+
+	_, err := PickFromJson(jsonStr, func(p *Picker) string {
+		users := p.NestedArray("users")
+		users.At(3)
+		return users.At(1).GetString("name")
+	})
+
+	detail := Detail(err)
+	if len(detail) != 2 {
+		t.Fatalf("expected 2 errors, got %v", detail)
+	}
+	if detail["users[3]"] != ErrorMissing {
+		t.Errorf("expected users[3] to be missing, got %v", detail)
+	}
+	if detail["users[1].name"] != ErrorMissing {
+		t.Errorf("expected users[1].name to be missing, got %v", detail)
+	}
+}
+
+func TestGetTypedArrayConvertsNumbers(t *testing.T) {
+	ids, err := PickFromJson(`{"ids": [1, 2.0, 3]}`, func(p *Picker) []int64 {
+		return GetTypedArray[int64](p, "ids")
+	})
+	if err != nil || len(ids) != 3 || ids[1] != 2 {
+		t.Errorf("expected [1 2 3], got %v, %v", ids, err)
+	}
+
+	_, err = PickFromJson(`{"ids": [1, "two"]}`, func(p *Picker) []int64 {
+		return GetTypedArray[int64](p, "ids")
+	})
+	detail := Detail(err)
+	if len(detail) != 1 || detail["ids[1]"] != ErrorInvalid {
+		t.Errorf("expected only ids[1] to be invalid, got %v", detail)
+	}
+}
+
+func TestApplyPatch(t *testing.T) {
+	doc := `{"a": {"b": 1}, "list": [1, 2, 3]}`
+	tests := []struct {
+		name  string
+		patch string
+		want  string
+		err   string
+	}{
+		{"add key", `[{"op": "add", "path": "/a/c", "value": 2}]`,
+			`{"a": {"b": 1, "c": 2}, "list": [1, 2, 3]}`, ""},
+		{"add insert", `[{"op": "add", "path": "/list/1", "value": 9}]`,
+			`{"a": {"b": 1}, "list": [1, 9, 2, 3]}`, ""},
+		{"add append", `[{"op": "add", "path": "/list/-", "value": 4}]`,
+			`{"a": {"b": 1}, "list": [1, 2, 3, 4]}`, ""},
+		{"add whole document", `[{"op": "add", "path": "", "value": {"x": 1}}]`,
+			`{"x": 1}`, ""},
+		{"add index out of range", `[{"op": "add", "path": "/list/5", "value": 4}]`,
+			"", "patch operation 0 (add): index 5 out of range"},
+		{"remove key", `[{"op": "remove", "path": "/a/b"}]`,
+			`{"a": {}, "list": [1, 2, 3]}`, ""},
+		{"remove element", `[{"op": "remove", "path": "/list/0"}]`,
+			`{"a": {"b": 1}, "list": [2, 3]}`, ""},
+		{"remove missing", `[{"op": "remove", "path": "/a/x"}]`,
+			"", "patch operation 0 (remove): path not found"},
+		{"replace key", `[{"op": "replace", "path": "/a/b", "value": "x"}]`,
+			`{"a": {"b": "x"}, "list": [1, 2, 3]}`, ""},
+		{"replace element", `[{"op": "replace", "path": "/list/2", "value": 0}]`,
+			`{"a": {"b": 1}, "list": [1, 2, 0]}`, ""},
+		{"replace missing", `[{"op": "replace", "path": "/z", "value": 0}]`,
+			"", "patch operation 0 (replace): path not found"},
+		{"move key", `[{"op": "move", "from": "/a/b", "path": "/b"}]`,
+			`{"a": {}, "b": 1, "list": [1, 2, 3]}`, ""},
+		{"move element", `[{"op": "move", "from": "/list/0", "path": "/list/-"}]`,
+			`{"a": {"b": 1}, "list": [2, 3, 1]}`, ""},
+		{"move into itself", `[{"op": "move", "from": "/a", "path": "/a/c"}]`,
+			"", "patch operation 0 (move): cannot move /a into itself"},
+		{"copy", `[{"op": "copy", "from": "/a", "path": "/c"}]`,
+			`{"a": {"b": 1}, "c": {"b": 1}, "list": [1, 2, 3]}`, ""},
+		{"test passes", `[{"op": "test", "path": "/list", "value": [1, 2, 3]}]`,
+			doc, ""},
+		{"test fails", `[{"op": "test", "path": "/a/b", "value": 2}]`,
+			"", "patch operation 0 (test): test failed at /a/b"},
+		{"unknown op", `[{"op": "merge", "path": "/a"}]`,
+			"", "patch operation 0 (merge): unknown operation"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, _ := ParseJson(doc)
+			p := newPicker(data)
+			err := p.ApplyPatch(tt.patch)
+			if tt.err != "" {
+				if err == nil || err.Error() != tt.err {
+					t.Fatalf("expected error %q, got %v", tt.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			want, _ := ParseJson(tt.want)
+			if !p.Equal(newPicker(want)) {
+				t.Errorf("expected %s, got %v", tt.want, p.data)
+			}
+		})
+	}
+}
+
+func TestApplyPatchIsAtomic(t *testing.T) {
+	data, _ := ParseJson(`{"a": 1, "list": [1, 2]}`)
+	p := newPicker(data)
+	before := p.Copy()
+
+	err := p.ApplyPatch(`[
+		{"op": "add", "path": "/b", "value": 2},
+		{"op": "remove", "path": "/list/0"},
+		{"op": "replace", "path": "/missing", "value": 3}
+	]`)
+
+	if err == nil || err.Error() != "patch operation 2 (replace): path not found" {
+		t.Fatalf("expected operation 2 to fail, got %v", err)
+	}
+	if !p.Equal(before) {
+		t.Errorf("expected data to be unchanged, got %v", p.data)
+	}
+}
@@ -0,0 +1,71 @@
+package picker
+
+import (
+	"strconv"
+	"strings"
+)
+
+// GetByPointer resolves an RFC 6901 JSON Pointer like "/body/postings/0/url"
+// without recording an error. The empty pointer refers to the whole data.
+func (p *Picker) GetByPointer(ptr string) (interface{}, bool) {
+	tokens, ok := parsePointer(ptr)
+	if !ok {
+		return nil, false
+	}
+	return resolvePointer(p.data, tokens)
+}
+
+func resolvePointer(node interface{}, tokens []string) (interface{}, bool) {
+	current := node
+	for _, token := range tokens {
+		switch v := current.(type) {
+		case map[string]interface{}:
+			child, ok := v[token]
+			if !ok {
+				return nil, false
+			}
+			current = child
+		case []interface{}:
+			index, ok := pointerIndex(token, len(v))
+			if !ok {
+				return nil, false
+			}
+			current = v[index]
+		default:
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+func parsePointer(ptr string) ([]string, bool) {
+	if ptr == "" {
+		return []string{}, true
+	}
+	if ptr[0] != '/' {
+		return nil, false
+	}
+	tokens := strings.Split(ptr[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, true
+}
+
+// pointerIndex parses an array index token. Leading zeros are not allowed
+// and the index must be within the array.
+func pointerIndex(token string, length int) (int, bool) {
+	if token == "" || (len(token) > 1 && token[0] == '0') {
+		return 0, false
+	}
+	for _, c := range token {
+		if c < '0' || c > '9' {
+			return 0, false
+		}
+	}
+	index, err := strconv.Atoi(token)
+	if err != nil || index >= length {
+		return 0, false
+	}
+	return index, true
+}
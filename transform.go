@@ -0,0 +1,459 @@
+package picker
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Pick returns a new picker with a deep copy of only the given keys. Keys
+// can be dot-delimited paths, in which case the objects along the path are
+// kept. Paths with array indexes are ignored, since the arrays around them
+// cannot be kept in part.
+func (p *Picker) Pick(keys ...string) *Picker {
+	picked := p.derive(map[string]interface{}{})
+	for _, key := range keys {
+		if strings.Contains(key, "[") {
+			continue
+		}
+		value, ok := p.lookupPath(key)
+		if _, invalid := value.(pathError); !ok || invalid {
+			continue
+		}
+		picked.SetPath(key, deepCopy(value))
+	}
+	return picked
+}
+
+// Omit returns a deep copy without the given keys. Keys can be dot-delimited
+// paths and, as with Pick, paths with array indexes are ignored.
+func (p *Picker) Omit(keys ...string) *Picker {
+	omitted := p.Copy()
+	for _, key := range keys {
+		if strings.Contains(key, "[") {
+			continue
+		}
+		omitted.DelPath(key)
+	}
+	return omitted
+}
+
+// Rename moves the value of oldKey to newKey, replacing any existing value,
+// and reports whether oldKey existed.
+func (p *Picker) Rename(oldKey string, newKey string) bool {
+	matched, ok := matchKey(p.data, oldKey, p.caseInsensitive)
+	if !ok {
+		return false
+	}
+	value := p.data[matched]
+	delete(p.data, matched)
+	if existing, ok := matchKey(p.data, newKey, p.caseInsensitive); ok {
+		newKey = existing
+	}
+	p.data[newKey] = value
+	return true
+}
+
+// RenamePath is Rename for dot-delimited paths. It returns false and leaves
+// the data unchanged if oldPath does not exist, ends in an array index, lies
+// above newPath, or the value cannot be set at newPath.
+func (p *Picker) RenamePath(oldPath string, newPath string) bool {
+	value, ok := p.lookupPath(oldPath)
+	if _, invalid := value.(pathError); !ok || invalid {
+		return false
+	}
+	if oldPath == newPath {
+		return true
+	}
+	if strings.HasPrefix(newPath, oldPath+".") || strings.HasPrefix(newPath, oldPath+"[") {
+		return false
+	}
+	if !p.DelPath(oldPath) {
+		return false
+	}
+	if err := p.SetPath(newPath, value); err != nil {
+		p.SetPath(oldPath, value)
+		return false
+	}
+	return true
+}
+
+type MergeStrategy int
+
+const (
+	// OverwriteExisting replaces existing keys with the other picker's values.
+	OverwriteExisting MergeStrategy = iota
+	// KeepExisting only adds keys that are not already present.
+	KeepExisting
+	// DeepMerge combines nested objects recursively. Other values are
+	// replaced, as with OverwriteExisting.
+	DeepMerge
+)
+
+// Merge copies the other picker's top-level keys into this picker. Values
+// are deep copied, so the two pickers never share nested objects.
+func (p *Picker) Merge(other *Picker, strategy MergeStrategy) {
+	if other == nil {
+		return
+	}
+	if p.data == nil {
+		p.data = map[string]interface{}{}
+	}
+	mergeMaps(p.data, other.data, strategy)
+}
+
+func mergeMaps(dst map[string]interface{}, src map[string]interface{}, strategy MergeStrategy) {
+	for key, value := range src {
+		existing, exists := dst[key]
+		switch {
+		case !exists:
+			dst[key] = deepCopy(value)
+		case strategy == KeepExisting:
+		case strategy == DeepMerge:
+			dstMap, dstOk := existing.(map[string]interface{})
+			srcMap, srcOk := value.(map[string]interface{})
+			if dstOk && srcOk {
+				mergeMaps(dstMap, srcMap, strategy)
+			} else {
+				dst[key] = deepCopy(value)
+			}
+		default:
+			dst[key] = deepCopy(value)
+		}
+	}
+}
+
+// Copy returns a new picker with a deep copy of the data, so nested objects
+// and arrays can be changed without affecting the original.
+func (p *Picker) Copy() *Picker {
+	data, _ := deepCopy(p.data).(map[string]interface{})
+	if data == nil {
+		data = map[string]interface{}{}
+	}
+	return p.derive(data)
+}
+
+// derive returns a new top-level picker for data with the same settings as
+// p, for the methods that return a reshaped copy.
+func (p *Picker) derive(data map[string]interface{}) *Picker {
+	derived := newPicker(data)
+	derived.caseInsensitive = p.caseInsensitive
+	return derived
+}
+
+func deepCopy(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		copied := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			copied[key] = deepCopy(item)
+		}
+		return copied
+	case []interface{}:
+		copied := make([]interface{}, len(v))
+		for i, item := range v {
+			copied[i] = deepCopy(item)
+		}
+		return copied
+	default:
+		return v
+	}
+}
+
+// ToMap returns a deep copy of the data as plain maps and slices, ready for
+// json.Marshal. Pickers and picker arrays stored as values are unwrapped.
+func (p *Picker) ToMap() map[string]interface{} {
+	data, _ := plainValue(p.data).(map[string]interface{})
+	if data == nil {
+		data = map[string]interface{}{}
+	}
+	return data
+}
+
+func plainValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case *Picker:
+		return plainValue(v.data)
+	case *NestedPickerArray:
+		items := make([]interface{}, len(v.Items))
+		for i, item := range v.Items {
+			items[i] = plainValue(item.data)
+		}
+		return items
+	case map[string]interface{}:
+		copied := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			copied[key] = plainValue(item)
+		}
+		return copied
+	case []interface{}:
+		copied := make([]interface{}, len(v))
+		for i, item := range v {
+			copied[i] = plainValue(item)
+		}
+		return copied
+	default:
+		return v
+	}
+}
+
+type PruneOption int
+
+const (
+	PruneNull PruneOption = 1 << iota
+	PruneEmptyString
+	PruneEmptyArray
+	// PruneEmptyObject also removes objects that are left empty after their
+	// own keys were pruned.
+	PruneEmptyObject
+	PruneAll = PruneNull | PruneEmptyString | PruneEmptyArray | PruneEmptyObject
+)
+
+// Prune returns a copy without keys whose values are null, empty strings,
+// empty arrays or empty objects, at any depth. Array elements are cleaned
+// but never removed, so indexes stay the same.
+func (p *Picker) Prune() *Picker {
+	return p.PruneWith(PruneAll)
+}
+
+// PruneWith is Prune that only removes the kinds of empty value given, like
+// PruneNull|PruneEmptyString.
+func (p *Picker) PruneWith(options PruneOption) *Picker {
+	data, _ := pruneValue(p.data, options).(map[string]interface{})
+	if data == nil {
+		data = map[string]interface{}{}
+	}
+	return p.derive(data)
+}
+
+func pruneValue(value interface{}, options PruneOption) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		pruned := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			item = pruneValue(item, options)
+			if !isPruned(item, options) {
+				pruned[key] = item
+			}
+		}
+		return pruned
+	case []interface{}:
+		pruned := make([]interface{}, len(v))
+		for i, item := range v {
+			pruned[i] = pruneValue(item, options)
+		}
+		return pruned
+	default:
+		return v
+	}
+}
+
+func isPruned(value interface{}, options PruneOption) bool {
+	switch v := value.(type) {
+	case nil:
+		return options&PruneNull != 0
+	case string:
+		return v == "" && options&PruneEmptyString != 0
+	case []interface{}:
+		return len(v) == 0 && options&PruneEmptyArray != 0
+	case map[string]interface{}:
+		return len(v) == 0 && options&PruneEmptyObject != 0
+	}
+	return false
+}
+
+// Redacted is the placeholder Redact puts in place of a value.
+var Redacted = "[REDACTED]"
+
+// Redact returns a deep copy with the values at the given dot-delimited paths
+// replaced by Redacted. The keys stay present, and paths that do not exist
+// are skipped.
+func (p *Picker) Redact(paths ...string) *Picker {
+	redacted := p.Copy()
+	for _, path := range paths {
+		redactPath(redacted.data, strings.Split(path, "."), p.caseInsensitive)
+	}
+	return redacted
+}
+
+func redactPath(data map[string]interface{}, segments []string, fold bool) {
+	name, indexes, ok := parseSegment(segments[0])
+	if !ok {
+		return
+	}
+	key, ok := matchKey(data, name, fold)
+	if !ok {
+		return
+	}
+	last := len(segments) == 1
+	if last && len(indexes) == 0 {
+		data[key] = Redacted
+		return
+	}
+	current := data[key]
+	for i, index := range indexes {
+		arr, ok := current.([]interface{})
+		if !ok {
+			return
+		}
+		if index < 0 {
+			index += len(arr)
+		}
+		if index < 0 || index >= len(arr) {
+			return
+		}
+		if last && i == len(indexes)-1 {
+			arr[index] = Redacted
+			return
+		}
+		current = arr[index]
+	}
+	if obj, ok := current.(map[string]interface{}); ok {
+		redactPath(obj, segments[1:], fold)
+	}
+}
+
+// Flatten returns a copy where nested objects are replaced by dot-delimited
+// keys, like "user.profile.email". Empty objects are kept as values.
+func (p *Picker) Flatten() *Picker {
+	return p.FlattenWith(".")
+}
+
+// FlattenWith is Flatten with a custom separator, such as "/" or "__". It
+// also returns a copy.
+func (p *Picker) FlattenWith(sep string) *Picker {
+	flat := map[string]interface{}{}
+	flattenValue(flat, "", sep, false, p.data)
+	return p.derive(flat)
+}
+
+// FlattenDeep is Flatten that also descends into arrays, using the index as
+// the key, like "postings.0.id". Empty arrays are kept as values. It also
+// returns a copy.
+func (p *Picker) FlattenDeep() *Picker {
+	flat := map[string]interface{}{}
+	flattenValue(flat, "", ".", true, p.data)
+	return p.derive(flat)
+}
+
+func flattenValue(flat map[string]interface{}, path string, sep string, deep bool, value interface{}) {
+	join := func(key string) string {
+		if path == "" {
+			return key
+		}
+		return path + sep + key
+	}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if len(v) > 0 || path == "" {
+			for key, item := range v {
+				flattenValue(flat, join(key), sep, deep, item)
+			}
+			return
+		}
+	case []interface{}:
+		if deep && len(v) > 0 {
+			for i, item := range v {
+				flattenValue(flat, join(strconv.Itoa(i)), sep, deep, item)
+			}
+			return
+		}
+	}
+	flat[path] = deepCopy(value)
+}
+
+// Unflatten is the inverse of Flatten. It returns an error if a key is used
+// both as a value and as a prefix, like "user" and "user.name".
+func (p *Picker) Unflatten() (*Picker, error) {
+	return p.UnflattenWith(".")
+}
+
+// UnflattenWith is Unflatten with a custom separator.
+func (p *Picker) UnflattenWith(sep string) (*Picker, error) {
+	data := map[string]interface{}{}
+	// created holds the prefixes of objects built here, as opposed to
+	// objects that were values in the flat data. Only an empty value can
+	// take nested keys.
+	created := map[string]bool{}
+	for _, key := range sortedKeys(p.data) {
+		segments := strings.Split(key, sep)
+		current := data
+		for i, segment := range segments[:len(segments)-1] {
+			prefix := strings.Join(segments[:i+1], sep)
+			next, exists := current[segment]
+			if !exists {
+				obj := map[string]interface{}{}
+				current[segment] = obj
+				created[prefix] = true
+				current = obj
+				continue
+			}
+			obj, ok := next.(map[string]interface{})
+			if !ok || (!created[prefix] && len(obj) > 0) {
+				return nil, errors.New("conflicting keys at " + key)
+			}
+			created[prefix] = true
+			current = obj
+		}
+		last := segments[len(segments)-1]
+		if _, exists := current[last]; exists {
+			return nil, errors.New("conflicting keys at " + key)
+		}
+		current[last] = deepCopy(p.data[key])
+	}
+	return p.derive(data), nil
+}
+
+// Equal reports whether both pickers hold the same data. Numbers are
+// compared by value, so json.Number("30"), float64(30) and int64(30) are
+// equal. A nil picker only equals another nil picker.
+func (p *Picker) Equal(other *Picker) bool {
+	if p == nil || other == nil {
+		return p == other
+	}
+	return equalValues(p.data, other.data)
+}
+
+func equalValues(a interface{}, b interface{}) bool {
+	switch av := a.(type) {
+	case map[string]interface{}:
+		bv, ok := b.(map[string]interface{})
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for key, item := range av {
+			other, ok := bv[key]
+			if !ok || !equalValues(item, other) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		bv, ok := b.([]interface{})
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for i := range av {
+			if !equalValues(av[i], bv[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	if an, ok := numberValue(a); ok {
+		bn, ok := numberValue(b)
+		return ok && an.Cmp(bn) == 0
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func numberValue(value interface{}) (*big.Rat, bool) {
+	switch value.(type) {
+	case json.Number, float64, int64, int, *big.Int, *big.Float, *big.Rat:
+		return toBigRat(value)
+	}
+	return nil, false
+}
@@ -0,0 +1,93 @@
+package picker
+
+import (
+	"sort"
+	"strconv"
+)
+
+// Walk calls fn for every leaf value in the data, in key order. Paths use the
+// same syntax as the path getters, like "body.postings[0].url".
+func (p *Picker) Walk(fn func(string, interface{})) {
+	walkValue("", p.data, fn)
+}
+
+func walkValue(path string, value interface{}, fn func(string, interface{})) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for _, key := range sortedKeys(v) {
+			walkValue(joinPath(path, key), v[key], fn)
+		}
+	case []interface{}:
+		for i, item := range v {
+			walkValue(path+"["+strconv.Itoa(i)+"]", item, fn)
+		}
+	default:
+		fn(path, v)
+	}
+}
+
+// WalkMutate replaces every leaf value in place with the value returned by fn.
+func (p *Picker) WalkMutate(fn func(string, interface{}) interface{}) {
+	mutateValue("", p.data, fn)
+}
+
+func mutateValue(path string, value interface{}, fn func(string, interface{}) interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for _, key := range sortedKeys(v) {
+			v[key] = mutateValue(joinPath(path, key), v[key], fn)
+		}
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = mutateValue(path+"["+strconv.Itoa(i)+"]", item, fn)
+		}
+		return v
+	default:
+		return fn(path, v)
+	}
+}
+
+// Paths maps the path of every leaf value to its type. Empty objects and
+// arrays count as leaves, so they appear as TypeObject and TypeArray.
+func (p *Picker) Paths() map[string]ValueType {
+	paths := map[string]ValueType{}
+	collectPaths(paths, "", p.data)
+	return paths
+}
+
+func collectPaths(paths map[string]ValueType, path string, value interface{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if len(v) > 0 || path == "" {
+			for key, item := range v {
+				collectPaths(paths, joinPath(path, key), item)
+			}
+			return
+		}
+	case []interface{}:
+		if len(v) > 0 {
+			for i, item := range v {
+				collectPaths(paths, path+"["+strconv.Itoa(i)+"]", item)
+			}
+			return
+		}
+	}
+	paths[path] = valueTypeOf(value)
+}
+
+func joinPath(path string, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func sortedKeys(data map[string]interface{}) []string {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,116 @@
+package picker
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+func PickFromYaml[T any](yamlStr string, fn func(*Picker) T) (T, error) {
+	data, err := ParseYaml(yamlStr)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return Pick(data, fn)
+}
+
+func ParseYaml(yamlStr string) (map[string]interface{}, error) {
+	var doc yaml.Node
+	err := yaml.Unmarshal([]byte(yamlStr), &doc)
+	if err != nil {
+		return nil, err
+	}
+	keepTimestamps(&doc)
+	var raw interface{}
+	err = doc.Decode(&raw)
+	if err != nil {
+		return nil, err
+	}
+	if raw == nil {
+		return map[string]interface{}{}, nil
+	}
+	data, ok := normalizeYaml(raw).(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("yaml: expected a mapping at the top level, got %T", raw)
+	}
+	return data, nil
+}
+
+// keepTimestamps retags timestamps as strings, so they keep the text they
+// were written with, like "2025-01-13", and parse with the same getters as
+// in JSON.
+func keepTimestamps(node *yaml.Node) {
+	if node.Kind == yaml.ScalarNode && node.ShortTag() == "!!timestamp" {
+		node.Tag = "!!str"
+	}
+	for _, child := range node.Content {
+		keepTimestamps(child)
+	}
+}
+
+// normalizeYaml converts decoded YAML into the same shapes the JSON parser
+// produces: string keys and json.Number integers.
+func normalizeYaml(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = normalizeYaml(item)
+		}
+		return v
+	case map[interface{}]interface{}:
+		converted := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			converted[fmt.Sprint(key)] = normalizeYaml(item)
+		}
+		return converted
+	case []interface{}:
+		for i, item := range v {
+			v[i] = normalizeYaml(item)
+		}
+		return v
+	case int:
+		return json.Number(strconv.Itoa(v))
+	case int64:
+		return json.Number(strconv.FormatInt(v, 10))
+	case uint64:
+		return json.Number(strconv.FormatUint(v, 10))
+	default:
+		return v
+	}
+}
+
+// ToYamlString returns the data as a YAML document, or an empty document if
+// it cannot be marshaled.
+func (p *Picker) ToYamlString() string {
+	out, err := yaml.Marshal(yamlValue(p.data))
+	if err != nil {
+		return "{}\n"
+	}
+	return string(out)
+}
+
+// yamlValue writes json.Number as a plain YAML number with its exact text,
+// since YAML would otherwise quote it as a string.
+func yamlValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		converted := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			converted[key] = yamlValue(item)
+		}
+		return converted
+	case []interface{}:
+		converted := make([]interface{}, len(v))
+		for i, item := range v {
+			converted[i] = yamlValue(item)
+		}
+		return converted
+	case json.Number:
+		return &yaml.Node{Kind: yaml.ScalarNode, Value: string(v)}
+	default:
+		return v
+	}
+}